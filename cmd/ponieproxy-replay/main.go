@@ -0,0 +1,195 @@
+/*
+ * ponieproxy-replay re-confirms IDOR candidates after the fact, against
+ * a capture directory already written by ponieproxy: for every *.req
+ * file whose query carries an IDOR-shaped param, it replays the request
+ * under a second identity and reports which ones come back with
+ * substantially the same content.
+ *
+ * Usage:
+ *   ponieproxy-replay -dir ./out -alt-auth ./alt-auth.txt
+ */
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ctoyan/ponieproxy/internal/replay"
+)
+
+func main() {
+	dir := flag.String("dir", "", "capture output directory containing *.req/*.res files")
+	altAuthFile := flag.String("alt-auth", "", "path to the alternate-identity auth file")
+	concurrency := flag.Int("concurrency", 4, "max concurrent replay requests")
+	flag.Parse()
+
+	if *dir == "" || *altAuthFile == "" {
+		fmt.Fprintln(os.Stderr, "usage: ponieproxy-replay -dir <capture dir> -alt-auth <file>")
+		os.Exit(2)
+	}
+
+	identity, err := replay.LoadIdentity(*altAuthFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error loading alt-auth file: %v\n", err)
+		os.Exit(1)
+	}
+	replayer := replay.NewReplayer(identity, *concurrency)
+
+	reqFiles, err := filepath.Glob(filepath.Join(*dir, "*.req"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error listing capture dir: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, reqFile := range reqFiles {
+		reqFile := reqFile
+		replayer.Pool.Go(func() {
+			if err := replayOne(replayer, reqFile); err != nil {
+				fmt.Fprintf(os.Stderr, "%v: %v\n", reqFile, err)
+			}
+		})
+	}
+	replayer.Pool.Wait()
+}
+
+func replayOne(replayer *replay.Replayer, reqFile string) error {
+	req, err := parseCapturedRequest(reqFile)
+	if err != nil {
+		return fmt.Errorf("error parsing captured request: %w", err)
+	}
+
+	flagged := false
+	for queryParam := range req.URL.Query() {
+		for _, idorParam := range []string{"account", "doc", "edit", "email", "group", "id", "key", "no", "number", "order", "profile", "report", "user"} {
+			if strings.Contains(strings.ToLower(queryParam), idorParam) {
+				flagged = true
+			}
+		}
+	}
+	if !flagged {
+		return nil
+	}
+
+	resFile := strings.TrimSuffix(reqFile, ".req") + ".res"
+	origStatus, origBody, err := parseCapturedResponse(resFile)
+	if err != nil {
+		return fmt.Errorf("error parsing captured response: %w", err)
+	}
+
+	altRes, err := replayer.Replay(req.Context(), req)
+	if err != nil {
+		return fmt.Errorf("error replaying request: %w", err)
+	}
+	defer altRes.Body.Close()
+
+	altBody, err := replay.ReadCapped(altRes.Body, replay.DefaultBodyCap)
+	if err != nil {
+		return fmt.Errorf("error reading replayed response: %w", err)
+	}
+
+	result := replay.Diff(origStatus, origBody, altRes.StatusCode, altBody)
+	if altRes.StatusCode >= 200 && altRes.StatusCode < 300 && result.SimilarContent {
+		fmt.Printf("CONFIRMED %v -> alt identity received %v, %.0f%% content overlap\n",
+			reqFile, altRes.StatusCode, result.Similarity*100)
+	}
+
+	return nil
+}
+
+// parseCapturedRequest reads a .req file written by WriteUniqueFile: a
+// request-line + headers block in DumpRequest format, followed by the
+// raw body. It is deliberately tolerant of a Content-Length header that
+// no longer matches the body, since the capture format appends the body
+// after the dump rather than before it.
+func parseCapturedRequest(path string) (*http.Request, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := textproto.NewReader(bufio.NewReader(strings.NewReader(string(raw))))
+
+	requestLine, err := reader.ReadLine()
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.SplitN(requestLine, " ", 3)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("malformed request line: %q", requestLine)
+	}
+	method, requestURI := parts[0], parts[1]
+
+	header, err := reader.ReadMIMEHeader()
+	if err != nil && len(header) == 0 {
+		return nil, err
+	}
+
+	// WriteUniqueFile joins the dump and the body with a literal space
+	// (fmt.Sprintf("%v %v", httpDump, body)) rather than a blank line, so
+	// the byte right after the MIME header terminator is that separator,
+	// not the body itself.
+	body := strings.TrimPrefix(readRemainder(reader.R), " ")
+
+	// DumpRequest only preserves the request-URI (path+query), not an
+	// absolute URL, so it's reassembled from the Host header. The
+	// capture format doesn't record which scheme was used either;
+	// https is the reasonable default for anything worth replaying.
+	host := header.Get("Host")
+	fullURL := fmt.Sprintf("https://%v%v", host, requestURI)
+
+	req, err := http.NewRequest(method, fullURL, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		for _, v := range values {
+			req.Header.Add(name, v)
+		}
+	}
+	req.Host = host
+
+	return req, nil
+}
+
+// parseCapturedResponse reads a .res file: the status line, headers,
+// and the response body.
+func parseCapturedResponse(path string) (int, []byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer f.Close()
+
+	res, err := http.ReadResponse(bufio.NewReader(f), nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := replay.ReadCapped(res.Body, replay.DefaultBodyCap)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return res.StatusCode, body, nil
+}
+
+func readRemainder(r *bufio.Reader) string {
+	var sb strings.Builder
+	buf := make([]byte, 4096)
+	for {
+		n, err := r.Read(buf)
+		sb.Write(buf[:n])
+		if err != nil {
+			break
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,124 @@
+package customFilters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/ctoyan/ponieproxy/internal/config"
+	"github.com/ctoyan/ponieproxy/internal/filters"
+	"github.com/ctoyan/ponieproxy/internal/notify"
+	"github.com/ctoyan/ponieproxy/internal/replay"
+	"github.com/elazarl/goproxy"
+)
+
+/* Response filter
+ * ConfirmIDOR takes DetectIDOR's param-name heuristic one step further:
+ * for every response whose request carried an IDOR-shaped param, it
+ * replays that request under a second identity (-alt-auth-file) and only
+ * alerts if the alternate identity also gets a 2xx with substantially
+ * similar content, i.e. the same record is reachable regardless of who's
+ * asking.
+ */
+func ConfirmIDOR(f *config.Flags, n notify.Notifier) filters.ResponseFilter {
+	matcher := sharedURLMatcher(f.URLFile)
+
+	identity, err := replay.LoadIdentity(f.AltAuthFile)
+	if err != nil {
+		log.Fatalf("error loading alt-auth identity: %v", err)
+	}
+	replayer := replay.NewReplayer(identity, f.ReplayConcurrency)
+
+	return filters.ResponseFilter{
+		Conditions: []goproxy.RespCondition{matcher.RespCondition()},
+		Handler: func(res *http.Response, ctx *goproxy.ProxyCtx) *http.Response {
+			req := ctx.Req
+			if req == nil {
+				return res
+			}
+
+			ud := ctx.UserData.(filters.UserData)
+
+			flaggedParam := flaggedIDORParam(req, ud)
+			if flaggedParam == "" {
+				return res
+			}
+
+			origBody, err := replay.ReadCapped(res.Body, replay.DefaultBodyCap)
+			if err != nil {
+				return res
+			}
+			res.Body = ioutil.NopCloser(strings.NewReader(string(origBody)))
+
+			origStatus := res.StatusCode
+
+			replayer.Pool.Go(func() {
+				// ctx.Req's body was already read and closed by goproxy's
+				// transport when the original request went upstream, so the
+				// clone can't inherit it the way Replay normally would —
+				// it's rehydrated from the checksum-matched UserData instead.
+				altRes, err := replayer.ReplayWithBody(context.Background(), req, []byte(ud.ReqBody))
+				if err != nil {
+					fmt.Printf("error replaying request for IDOR confirmation: %v\n", err)
+					return
+				}
+				defer altRes.Body.Close()
+
+				altBody, err := replay.ReadCapped(altRes.Body, replay.DefaultBodyCap)
+				if err != nil {
+					return
+				}
+
+				result := replay.Diff(origStatus, origBody, altRes.StatusCode, altBody)
+				if altRes.StatusCode < 200 || altRes.StatusCode >= 300 || !result.SimilarContent {
+					return
+				}
+
+				n.Send(context.Background(), notify.Finding{
+					Type:     "IDOR-Confirmed",
+					URL:      req.URL.String(),
+					Method:   req.Method,
+					Param:    flaggedParam,
+					Checksum: ud.Checksum,
+					Detail:   fmt.Sprintf("alt identity received %v, %.0f%% content overlap", altRes.StatusCode, result.Similarity*100),
+				})
+			})
+
+			return res
+		},
+	}
+}
+
+// flaggedIDORParam looks for an IDOR-shaped param the same way DetectIDOR
+// does: in the query string, and — since DetectIDOR also raises findings
+// from the JSON request body via utils.DetectInJsonReqBody — in the
+// body's top-level JSON fields, so a body-based IDOR finding can still be
+// replayed and confirmed, not just a query-based one.
+func flaggedIDORParam(req *http.Request, ud filters.UserData) string {
+	for queryParam := range req.URL.Query() {
+		for _, idorParam := range idorParams {
+			if strings.Contains(strings.ToLower(queryParam), strings.ToLower(idorParam)) {
+				return queryParam
+			}
+		}
+	}
+
+	if ud.ReqBody != "" {
+		var bodyMap map[string]interface{}
+		if err := json.Unmarshal([]byte(ud.ReqBody), &bodyMap); err == nil {
+			for bodyParam := range bodyMap {
+				for _, idorParam := range idorParams {
+					if strings.Contains(strings.ToLower(bodyParam), strings.ToLower(idorParam)) {
+						return bodyParam
+					}
+				}
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,61 @@
+package customFilters
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ctoyan/ponieproxy/internal/filters"
+	"github.com/ctoyan/ponieproxy/pkg/utils"
+)
+
+// writeScopeFile writes n distinct scope lines to a temp file and
+// returns its path, for benchmarks that need a realistically sized
+// (e.g. 10k-URL) scope list.
+func writeScopeFile(tb testing.TB, n int) string {
+	tb.Helper()
+
+	f, err := ioutil.TempFile("", "ponieproxy-scope-*.txt")
+	if err != nil {
+		tb.Fatalf("error creating temp scope file: %v", err)
+	}
+	defer f.Close()
+
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(f, "https://example%d\\.com/path\n", i)
+	}
+
+	tb.Cleanup(func() { os.Remove(f.Name()) })
+	return f.Name()
+}
+
+// BenchmarkSharedURLMatcher measures repeated lookups of a 10k-URL scope
+// file through sharedURLMatcher, which compiles the alternation regex
+// once per file and serves every subsequent call from matcherCache.
+func BenchmarkSharedURLMatcher(b *testing.B) {
+	urlFile := writeScopeFile(b, 10000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sharedURLMatcher(urlFile)
+	}
+}
+
+// BenchmarkNewURLMatcherUncached measures the pre-caching behavior this
+// request replaced: every filter constructor reading the scope file and
+// compiling its own alternation regex from scratch.
+func BenchmarkNewURLMatcherUncached(b *testing.B) {
+	urlFile := writeScopeFile(b, 10000)
+	urls, err := utils.ReadLines(urlFile)
+	if err != nil {
+		b.Fatalf("error reading scope file: %v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := filters.NewURLMatcher(urls); err != nil {
+			b.Fatalf("error compiling url matcher: %v", err)
+		}
+	}
+}
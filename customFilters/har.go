@@ -0,0 +1,78 @@
+package customFilters
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ctoyan/ponieproxy/internal/config"
+	"github.com/ctoyan/ponieproxy/internal/filters"
+	"github.com/ctoyan/ponieproxy/internal/sinks/har"
+	"github.com/elazarl/goproxy"
+)
+
+// harFlushInterval controls how often the accumulated entries are
+// written out to -har-out. Matched pairs still show up immediately on
+// disk via WriteReq/WriteResp; this only governs the HAR file.
+const harFlushInterval = 5 * time.Second
+
+// harRecorder is shared between WriteHARReq and WriteHARResp so that a
+// request and its response end up correlated in the same HAR entry.
+var harRecorder *har.Recorder
+
+func getHARRecorder(f *config.Flags) *har.Recorder {
+	if harRecorder == nil {
+		harRecorder = har.NewRecorder(f.HarOut, harFlushInterval)
+	}
+	return harRecorder
+}
+
+/* Request filter
+ * Buffer the matched request into the HAR recorder, in addition to
+ * whatever WriteReq already does with the raw dump.
+ *
+ * Only active when -har-out is set.
+ */
+func WriteHARReq(f *config.Flags) filters.RequestFilter {
+	matcher := sharedURLMatcher(f.URLFile)
+	recorder := getHARRecorder(f)
+
+	return filters.RequestFilter{
+		Conditions: []goproxy.ReqCondition{matcher.ReqCondition()},
+		Handler: func(req *http.Request, ctx *goproxy.ProxyCtx) (*http.Request, *http.Response) {
+			ud := ctx.UserData.(filters.UserData)
+			recorder.AddRequest(ud.Checksum, req, []byte(ud.ReqBody))
+
+			return req, nil
+		},
+	}
+}
+
+/* Response filter
+ * Match the response against its buffered request by checksum and
+ * append the completed entry to the HAR recorder.
+ *
+ * Only active when -har-out is set.
+ */
+func WriteHARResp(f *config.Flags) filters.ResponseFilter {
+	matcher := sharedURLMatcher(f.URLFile)
+	recorder := getHARRecorder(f)
+
+	return filters.ResponseFilter{
+		Conditions: []goproxy.RespCondition{matcher.RespCondition()},
+		Handler: func(res *http.Response, ctx *goproxy.ProxyCtx) *http.Response {
+			resBody, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				fmt.Printf("error reading resBody: %v\n", err)
+			}
+			res.Body = ioutil.NopCloser(strings.NewReader(string(resBody)))
+
+			ud := ctx.UserData.(filters.UserData)
+			recorder.AddResponse(ud.Checksum, res, resBody)
+
+			return res
+		},
+	}
+}
@@ -0,0 +1,180 @@
+package customFilters
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ctoyan/ponieproxy/internal/config"
+	"github.com/ctoyan/ponieproxy/internal/filters"
+	"github.com/ctoyan/ponieproxy/internal/huntrules"
+	"github.com/ctoyan/ponieproxy/internal/notify"
+	"github.com/elazarl/goproxy"
+)
+
+// huntSnippetLen bounds how much of the body we attach to a finding, so
+// a multi-megabyte upload doesn't end up inline in a Slack message.
+const huntSnippetLen = 200
+
+/* Request filter
+ * DetectHUNT is the full HUNT methodology rule engine: it walks the URL
+ * query, form-encoded body, JSON body (recursively) and XML element
+ * names against an external, hot-reloadable ruleset, and dispatches
+ * every hit through the shared notifier.
+ *
+ * Unlike DetectIDOR, the vulnerability classes and their param-name
+ * substrings live in -hunt-rules-file rather than in the source, so new
+ * classes can be added without a rebuild. The ruleset is reloaded on
+ * SIGHUP.
+ */
+func DetectHUNT(f *config.Flags, n notify.Notifier) filters.RequestFilter {
+	matcher := sharedURLMatcher(f.URLFile)
+
+	rules, err := huntrules.Load(f.HuntRulesFile)
+	if err != nil {
+		log.Fatalf("error loading hunt rules: %v", err)
+	}
+	huntrules.WatchSIGHUP(rules, f.HuntRulesFile)
+
+	return filters.RequestFilter{
+		Conditions: []goproxy.ReqCondition{matcher.ReqCondition()},
+		Handler: func(req *http.Request, ctx *goproxy.ProxyCtx) (*http.Request, *http.Response) {
+			ud := ctx.UserData.(filters.UserData)
+			snapshot := rules.Snapshot()
+
+			for _, rule := range snapshot {
+				for queryParam := range req.URL.Query() {
+					if matchesParam(rule.Params, queryParam) {
+						notifyHunt(n, rule.Class, req, queryParam, ud, snippet(ud.ReqBody))
+					}
+				}
+			}
+
+			contentType := req.Header.Get("Content-Type")
+			switch {
+			case strings.Contains(contentType, "application/x-www-form-urlencoded"):
+				scanFormBody(rules, n, req, ud)
+			case strings.Contains(contentType, "application/json"):
+				scanJSONBody(rules, n, req, ud)
+			case strings.Contains(contentType, "xml") || strings.HasPrefix(strings.TrimSpace(ud.ReqBody), "<?xml"):
+				// XXE is flagged on shape alone, not on a param-name match.
+				notifyHunt(n, "XXE", req, "", ud, snippet(ud.ReqBody))
+				scanXMLBody(rules, n, req, ud)
+			}
+
+			return req, nil
+		},
+	}
+}
+
+func matchesParam(ruleParams []string, param string) bool {
+	for _, p := range ruleParams {
+		if strings.Contains(strings.ToLower(param), strings.ToLower(p)) {
+			return true
+		}
+	}
+	return false
+}
+
+func scanFormBody(rules *huntrules.RuleSet, n notify.Notifier, req *http.Request, ud filters.UserData) {
+	values, err := url.ParseQuery(ud.ReqBody)
+	if err != nil {
+		return
+	}
+
+	for _, rule := range rules.Snapshot() {
+		for param := range values {
+			if matchesParam(rule.Params, param) {
+				notifyHunt(n, rule.Class, req, param, ud, snippet(ud.ReqBody))
+			}
+		}
+	}
+}
+
+func scanJSONBody(rules *huntrules.RuleSet, n notify.Notifier, req *http.Request, ud filters.UserData) {
+	if ud.ReqBody == "" {
+		return
+	}
+
+	var body interface{}
+	if err := json.Unmarshal([]byte(ud.ReqBody), &body); err != nil {
+		return
+	}
+
+	fields := make(map[string]struct{})
+	collectJSONFields(body, fields)
+
+	for _, rule := range rules.Snapshot() {
+		for field := range fields {
+			if matchesParam(rule.Params, field) {
+				notifyHunt(n, rule.Class, req, field, ud, snippet(ud.ReqBody))
+			}
+		}
+	}
+}
+
+// collectJSONFields walks nested objects/arrays, collecting every object
+// key it finds so the rule engine can match against them regardless of
+// how deep they're nested.
+func collectJSONFields(v interface{}, fields map[string]struct{}) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for key, nested := range val {
+			fields[key] = struct{}{}
+			collectJSONFields(nested, fields)
+		}
+	case []interface{}:
+		for _, item := range val {
+			collectJSONFields(item, fields)
+		}
+	}
+}
+
+// scanXMLBody matches element names found anywhere in the document
+// against the ruleset, the same way JSON object keys are matched.
+// Malformed XML (including the truncated documents XXE payloads often
+// produce) simply stops the scan at the point the decoder gives up.
+func scanXMLBody(rules *huntrules.RuleSet, n notify.Notifier, req *http.Request, ud filters.UserData) {
+	fields := make(map[string]struct{})
+
+	decoder := xml.NewDecoder(strings.NewReader(ud.ReqBody))
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			fields[start.Name.Local] = struct{}{}
+		}
+	}
+
+	for _, rule := range rules.Snapshot() {
+		for field := range fields {
+			if matchesParam(rule.Params, field) {
+				notifyHunt(n, rule.Class, req, field, ud, snippet(ud.ReqBody))
+			}
+		}
+	}
+}
+
+func notifyHunt(n notify.Notifier, class string, req *http.Request, param string, ud filters.UserData, body string) {
+	go n.Send(context.Background(), notify.Finding{
+		Type:     class,
+		URL:      req.URL.String(),
+		Method:   req.Method,
+		Param:    param,
+		Checksum: ud.Checksum,
+		Snippet:  body,
+	})
+}
+
+func snippet(body string) string {
+	if len(body) <= huntSnippetLen {
+		return body
+	}
+	return body[:huntSnippetLen] + "..."
+}
@@ -0,0 +1,258 @@
+package customFilters
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/ctoyan/ponieproxy/internal/config"
+	"github.com/ctoyan/ponieproxy/internal/filters"
+	"github.com/ctoyan/ponieproxy/internal/notify"
+	"github.com/ctoyan/ponieproxy/pkg/utils"
+	"github.com/elazarl/goproxy"
+)
+
+// reflectedParamMinLen is the shortest param value worth checking for
+// verbatim reflection; anything shorter produces too many coincidental
+// matches to be useful as an XSS signal.
+const reflectedParamMinLen = 6
+
+// secretEntropyMinLen/secretEntropyThreshold bound the generic
+// base64-alphabet token check: long enough to be a credential, and random
+// enough (Shannon entropy) not to be e.g. a minified JS identifier. A
+// pure hex token tops out at 4 bits per character, so it never passes
+// the threshold.
+const (
+	secretEntropyMinLen    = 32
+	secretEntropyThreshold = 4.5
+)
+
+type secretPattern struct {
+	name string
+	re   *regexp.Regexp
+}
+
+var secretPatterns = []secretPattern{
+	{"AWS Access Key", regexp.MustCompile(`AKIA[0-9A-Z]{16}`)},
+	{"Google API Key", regexp.MustCompile(`AIza[0-9A-Za-z_\-]{35}`)},
+	{"Slack Token", regexp.MustCompile(`xox[baprs]-[A-Za-z0-9-]+`)},
+	{"JWT", regexp.MustCompile(`eyJ[A-Za-z0-9_\-]+\.eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+`)},
+	{"Private Key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)},
+}
+
+var genericTokenRe = regexp.MustCompile(`[A-Za-z0-9+/_=\-]{32,}`)
+
+/* Response filter
+ * ScanResponse is a passive scanner: it never alters traffic, only
+ * inspects the already-matched response for secrets, reflected input,
+ * and weak security headers, and reports whatever it finds through the
+ * shared notifier plus a per-checksum *.findings.json.
+ */
+func ScanResponse(f *config.Flags, n notify.Notifier) filters.ResponseFilter {
+	matcher := sharedURLMatcher(f.URLFile)
+
+	return filters.ResponseFilter{
+		Conditions: []goproxy.RespCondition{matcher.RespCondition()},
+		Handler: func(res *http.Response, ctx *goproxy.ProxyCtx) *http.Response {
+			ud := ctx.UserData.(filters.UserData)
+
+			resBody, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				return res
+			}
+			res.Body = ioutil.NopCloser(strings.NewReader(string(resBody)))
+			body := string(resBody)
+
+			findings := scanSecrets(ud.Checksum, body)
+			if ctx.Req != nil {
+				findings = append(findings, scanReflection(ctx.Req, ud, body)...)
+			}
+			findings = append(findings, auditHeaders(ctx.Req, res, ud.Checksum)...)
+
+			for _, finding := range findings {
+				go n.Send(context.Background(), finding)
+				if err := utils.AppendFinding(f.OutputDir, ud.Checksum, finding); err != nil {
+					log.Printf("error writing finding to disk: %v\n", err)
+				}
+			}
+
+			return res
+		},
+	}
+}
+
+func scanSecrets(checksum string, body string) []notify.Finding {
+	var findings []notify.Finding
+
+	for _, pattern := range secretPatterns {
+		for _, match := range pattern.re.FindAllString(body, -1) {
+			findings = append(findings, notify.Finding{
+				Type:     "Secret",
+				Checksum: checksum,
+				Detail:   pattern.name,
+				Snippet:  match,
+			})
+		}
+	}
+
+	for _, token := range genericTokenRe.FindAllString(body, -1) {
+		if len(token) >= secretEntropyMinLen && shannonEntropy(token) > secretEntropyThreshold {
+			findings = append(findings, notify.Finding{
+				Type:     "Secret",
+				Checksum: checksum,
+				Detail:   "high-entropy token",
+				Snippet:  token,
+			})
+		}
+	}
+
+	return findings
+}
+
+func scanReflection(req *http.Request, ud filters.UserData, body string) []notify.Finding {
+	var findings []notify.Finding
+
+	values := paramValues(req, ud)
+
+	for param, value := range values {
+		if len(value) < reflectedParamMinLen {
+			continue
+		}
+		if strings.Contains(body, value) {
+			findings = append(findings, notify.Finding{
+				Type:     "Reflected-XSS",
+				URL:      req.URL.String(),
+				Method:   req.Method,
+				Param:    param,
+				Checksum: ud.Checksum,
+				Snippet:  value,
+			})
+		}
+	}
+
+	return findings
+}
+
+// paramValues collects every query, form-body and JSON-body param value
+// on the request, keyed by param name, so scanReflection can check each
+// one for verbatim reflection regardless of where it travelled in.
+func paramValues(req *http.Request, ud filters.UserData) map[string]string {
+	values := make(map[string]string)
+	for param, vals := range req.URL.Query() {
+		for _, v := range vals {
+			values[param] = v
+		}
+	}
+
+	contentType := req.Header.Get("Content-Type")
+	switch {
+	case strings.Contains(contentType, "application/x-www-form-urlencoded"):
+		if formValues, err := url.ParseQuery(ud.ReqBody); err == nil {
+			for param, vals := range formValues {
+				for _, v := range vals {
+					values[param] = v
+				}
+			}
+		}
+	case strings.Contains(contentType, "application/json"):
+		if ud.ReqBody != "" {
+			var parsed interface{}
+			if err := json.Unmarshal([]byte(ud.ReqBody), &parsed); err == nil {
+				collectJSONValues(parsed, values)
+			}
+		}
+	}
+
+	return values
+}
+
+// collectJSONValues walks nested objects/arrays, collecting every string
+// leaf value keyed by its immediate object key, the same way hunt.go's
+// collectJSONFields collects keys rather than values.
+func collectJSONValues(v interface{}, values map[string]string) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for key, nested := range val {
+			switch nestedVal := nested.(type) {
+			case string:
+				values[key] = nestedVal
+			default:
+				collectJSONValues(nested, values)
+			}
+		}
+	case []interface{}:
+		for _, item := range val {
+			collectJSONValues(item, values)
+		}
+	}
+}
+
+func auditHeaders(req *http.Request, res *http.Response, checksum string) []notify.Finding {
+	var findings []notify.Finding
+	var url, method string
+	if req != nil {
+		url = req.URL.String()
+		method = req.Method
+	}
+
+	missing := func(header string) bool {
+		return strings.TrimSpace(res.Header.Get(header)) == ""
+	}
+
+	if missing("Content-Security-Policy") {
+		findings = append(findings, notify.Finding{
+			Type: "Header-Audit", URL: url, Method: method, Checksum: checksum,
+			Detail: "missing Content-Security-Policy",
+		})
+	}
+	if missing("Strict-Transport-Security") {
+		findings = append(findings, notify.Finding{
+			Type: "Header-Audit", URL: url, Method: method, Checksum: checksum,
+			Detail: "missing Strict-Transport-Security",
+		})
+	}
+	if missing("X-Frame-Options") {
+		findings = append(findings, notify.Finding{
+			Type: "Header-Audit", URL: url, Method: method, Checksum: checksum,
+			Detail: "missing X-Frame-Options",
+		})
+	}
+
+	acao := res.Header.Get("Access-Control-Allow-Origin")
+	acac := strings.EqualFold(res.Header.Get("Access-Control-Allow-Credentials"), "true")
+	if acao == "*" && acac {
+		findings = append(findings, notify.Finding{
+			Type: "Header-Audit", URL: url, Method: method, Checksum: checksum,
+			Detail: "Access-Control-Allow-Origin: * combined with Access-Control-Allow-Credentials: true",
+		})
+	}
+
+	return findings
+}
+
+// shannonEntropy measures randomness in bits per character, used to
+// separate likely secrets from ordinary long identifiers.
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+
+	var entropy float64
+	length := float64(len(s))
+	for _, count := range counts {
+		p := float64(count) / length
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
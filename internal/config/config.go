@@ -0,0 +1,60 @@
+package config
+
+import (
+	"flag"
+	"time"
+)
+
+/*
+ * Flags holds every setting the proxy's filter constructors need,
+ * populated once by ParseFlags and then passed around by pointer so
+ * every filter constructor shares the same values.
+ */
+type Flags struct {
+	URLFile   string // -url-file: scope file, one URL-matching regex fragment per line
+	OutputDir string // -output-dir: where captured *.req/*.res and per-checksum *.findings.json (passive scan findings included) go
+
+	HarOut string // -har-out: HAR 1.2 export path; HAR recording is disabled when empty
+
+	NotifyProviders   string        // -notify: comma-separated provider list, e.g. "slack,telegram"; empty disables notifications
+	SlackWebhookURL   string        // -slack-webhook-url
+	DiscordWebhookURL string        // -discord-webhook-url
+	TelegramBotToken  string        // -telegram-bot-token
+	TelegramChatID    string        // -telegram-chat-id
+	WebhookURL        string        // -webhook-url
+	WebhookTemplate   string        // -webhook-template: {{placeholder}} template; empty uses the default JSON shape
+	NotifyDedupWindow time.Duration // -notify-dedup-window: suppress repeat findings for the same checksum+type+param+detail within this window
+
+	HuntRulesFile string // -hunt-rules-file: YAML/JSON HUNT ruleset, hot-reloadable via SIGHUP
+
+	AltAuthFile       string // -alt-auth-file: second-identity cookie/Authorization file used to confirm IDOR findings
+	ReplayConcurrency int    // -replay-concurrency: max concurrent IDOR-confirmation replay requests
+}
+
+// ParseFlags registers every flag and parses os.Args, returning the
+// populated Flags. It is meant to be called once, from main.
+func ParseFlags() *Flags {
+	f := &Flags{}
+
+	flag.StringVar(&f.URLFile, "url-file", "", "file with one scope URL-matching regex fragment per line")
+	flag.StringVar(&f.OutputDir, "output-dir", "./", "directory captured requests/responses/findings are written to")
+
+	flag.StringVar(&f.HarOut, "har-out", "", "HAR 1.2 export path; HAR recording is disabled when empty")
+
+	flag.StringVar(&f.NotifyProviders, "notify", "", "comma-separated notifier providers to enable, e.g. \"slack,telegram\"")
+	flag.StringVar(&f.SlackWebhookURL, "slack-webhook-url", "", "Slack incoming webhook URL")
+	flag.StringVar(&f.DiscordWebhookURL, "discord-webhook-url", "", "Discord webhook URL")
+	flag.StringVar(&f.TelegramBotToken, "telegram-bot-token", "", "Telegram bot token")
+	flag.StringVar(&f.TelegramChatID, "telegram-chat-id", "", "Telegram chat ID to post findings to")
+	flag.StringVar(&f.WebhookURL, "webhook-url", "", "generic webhook URL")
+	flag.StringVar(&f.WebhookTemplate, "webhook-template", "", "generic webhook {{placeholder}} body template; empty uses the default JSON shape")
+	flag.DurationVar(&f.NotifyDedupWindow, "notify-dedup-window", 10*time.Minute, "suppress repeat findings for the same checksum+type+param+detail within this window")
+
+	flag.StringVar(&f.HuntRulesFile, "hunt-rules-file", "", "YAML/JSON HUNT ruleset; reloaded on SIGHUP")
+
+	flag.StringVar(&f.AltAuthFile, "alt-auth-file", "", "second-identity cookie/Authorization file used to confirm IDOR findings")
+	flag.IntVar(&f.ReplayConcurrency, "replay-concurrency", 4, "max concurrent IDOR-confirmation replay requests")
+
+	flag.Parse()
+	return f
+}
@@ -0,0 +1,45 @@
+package filters
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/elazarl/goproxy"
+)
+
+/*
+ * URLMatcher wraps a single precompiled alternation regex built from a
+ * scope file's lines. Every filter constructor used to read that file
+ * and build this same regex itself; URLMatcher lets them share one
+ * compiled matcher instead, see customFilters' sharedURLMatcher.
+ */
+type URLMatcher struct {
+	re *regexp.Regexp
+}
+
+// NewURLMatcher compiles urls (one scope entry per line) into a single
+// alternation: (LINE_ONE)|(LINE_TWO)|(LINE_THREE).
+func NewURLMatcher(urls []string) (*URLMatcher, error) {
+	re, err := regexp.Compile(fmt.Sprintf("(%v)", strings.Join(urls, ")|(")))
+	if err != nil {
+		return nil, fmt.Errorf("error compiling url matcher: %w", err)
+	}
+	return &URLMatcher{re: re}, nil
+}
+
+// MatchString reports whether s (typically a dumped request/response
+// line) matches the scope.
+func (m *URLMatcher) MatchString(s string) bool {
+	return m.re.MatchString(s)
+}
+
+// ReqCondition adapts the matcher to goproxy's request condition type.
+func (m *URLMatcher) ReqCondition() goproxy.ReqCondition {
+	return goproxy.UrlMatches(m.re)
+}
+
+// RespCondition adapts the matcher to goproxy's response condition type.
+func (m *URLMatcher) RespCondition() goproxy.RespCondition {
+	return goproxy.UrlMatches(m.re)
+}
@@ -0,0 +1,139 @@
+package huntrules
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+
+	"gopkg.in/yaml.v3"
+)
+
+/*
+ * Rule describes one vulnerability class from the HUNT methodology
+ * (https://github.com/bugcrowd/HUNT): a class name plus the param-name
+ * substrings that flag it. XXE is not a Rule; customFilters.DetectHUNT
+ * flags it from the request's content-type/body shape instead.
+ */
+type Rule struct {
+	Class  string   `json:"class" yaml:"class"`
+	Params []string `json:"params" yaml:"params"`
+}
+
+// DefaultRules mirrors the classes called out when this engine was
+// introduced, and is used whenever -hunt-rules-file is left unset.
+var DefaultRules = []Rule{
+	{Class: "SSRF", Params: []string{"dest", "redirect", "uri", "path", "continue", "url", "window", "next", "data", "reference", "site", "html", "val", "validate", "domain", "callback", "feed", "host", "port", "to", "out", "view", "dir", "show", "navigation", "open"}},
+	{Class: "SQLi", Params: []string{"id", "select", "report", "role", "update", "query", "user", "name", "sort", "where", "search", "params", "process", "row", "view", "table", "from", "order", "delete"}},
+	{Class: "SSTI", Params: []string{"template", "preview", "id", "view", "activity", "name", "content", "redirect"}},
+	{Class: "LFI", Params: []string{"file", "document", "folder", "root", "path", "pg", "style", "pdf", "template", "php_path", "doc"}},
+	{Class: "CMDi", Params: []string{"daemon", "upload", "dir", "execute", "download", "log", "ip", "cli", "cmd"}},
+	{Class: "Debug", Params: []string{"debug", "test", "admin"}},
+}
+
+/*
+ * RuleSet is the hot-reloadable collection of rules a running proxy
+ * consults. Reload swaps the rules slice under a lock, so in-flight
+ * Snapshot callers never see a half-updated set.
+ */
+type RuleSet struct {
+	mu    sync.RWMutex
+	rules []Rule
+}
+
+// New returns a RuleSet seeded with the given rules, or DefaultRules if
+// none are given.
+func New(rules []Rule) *RuleSet {
+	if len(rules) == 0 {
+		rules = DefaultRules
+	}
+	return &RuleSet{rules: rules}
+}
+
+// Snapshot returns the current rules. The slice is shared, not a copy;
+// it is safe to range over without further locking because Reload
+// replaces the slice rather than modifying it.
+func (rs *RuleSet) Snapshot() []Rule {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	return rs.rules
+}
+
+// Load reads a ruleset from path, picking the format (YAML or JSON) from
+// the file extension. An empty path yields DefaultRules.
+func Load(path string) (*RuleSet, error) {
+	if path == "" {
+		return New(nil), nil
+	}
+
+	rules, err := parseFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return New(rules), nil
+}
+
+// Reload re-reads path and atomically replaces the rule set. Intended to
+// be wired up to SIGHUP via WatchSIGHUP.
+func (rs *RuleSet) Reload(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	rules, err := parseFile(path)
+	if err != nil {
+		return err
+	}
+
+	rs.mu.Lock()
+	rs.rules = rules
+	rs.mu.Unlock()
+	return nil
+}
+
+// WatchSIGHUP reloads rs from path every time the process receives
+// SIGHUP, logging (but not dying on) reload errors so a bad edit to the
+// ruleset file doesn't take down an in-progress crawl.
+func WatchSIGHUP(rs *RuleSet, path string) {
+	if path == "" {
+		return
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGHUP)
+
+	go func() {
+		for range sig {
+			if err := rs.Reload(path); err != nil {
+				log.Printf("error reloading hunt rules from %v: %v", path, err)
+				continue
+			}
+			log.Printf("reloaded hunt rules from %v", path)
+		}
+	}()
+}
+
+func parseFile(path string) ([]Rule, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading hunt rules: %w", err)
+	}
+
+	var rules []Rule
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(raw, &rules)
+	default:
+		err = json.Unmarshal(raw, &rules)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing hunt rules: %w", err)
+	}
+	return rules, nil
+}
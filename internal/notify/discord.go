@@ -0,0 +1,83 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+/*
+ * DiscordNotifier posts a Finding to a Discord webhook as a single embed.
+ */
+type DiscordNotifier struct {
+	WebhookURL string
+	httpClient *http.Client
+}
+
+func NewDiscordNotifier(webhookURL string) *DiscordNotifier {
+	return &DiscordNotifier{
+		WebhookURL: webhookURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type discordMessage struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title  string         `json:"title"`
+	Fields []discordField `json:"fields"`
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+func (d *DiscordNotifier) Send(ctx context.Context, f Finding) error {
+	embed := discordEmbed{
+		Title: f.Type,
+		Fields: []discordField{
+			{Name: "URL", Value: f.URL, Inline: false},
+			{Name: "Method", Value: f.Method, Inline: true},
+			{Name: "Param", Value: f.Param, Inline: true},
+			{Name: "Checksum", Value: f.Checksum, Inline: false},
+		},
+	}
+	if f.Detail != "" {
+		embed.Fields = append(embed.Fields, discordField{Name: "Detail", Value: f.Detail, Inline: false})
+	}
+	if f.Snippet != "" {
+		embed.Fields = append(embed.Fields, discordField{
+			Name:  "Snippet",
+			Value: fmt.Sprintf("```%v```", f.Snippet),
+		})
+	}
+
+	body, err := json.Marshal(discordMessage{Embeds: []discordEmbed{embed}})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.WebhookURL, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notify: discord webhook returned status %v", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ctoyan/ponieproxy/internal/config"
+)
+
+/*
+ * Finding describes a single hunt hit, regardless of which filter or
+ * vulnerability class produced it. Every Notifier implementation renders
+ * this into its own wire format.
+ */
+type Finding struct {
+	Type     string // e.g. "IDOR", "SSRF", "SQLi", "Secret", "Reflected-XSS", "Header-Audit"
+	URL      string
+	Method   string
+	Param    string
+	Checksum string
+	Snippet  string
+	Detail   string // free-form context a Type alone doesn't convey, e.g. a missing header's name
+}
+
+/*
+ * Notifier delivers a Finding to some external channel.
+ */
+type Notifier interface {
+	Send(ctx context.Context, f Finding) error
+}
+
+/*
+ * New builds the shared Notifier described by the -notify flag, e.g.
+ * "-notify slack,telegram". Providers are fanned out to and wrapped with
+ * de-duplication, so a filter constructor only ever has to hold one
+ * Notifier instead of juggling webhook URLs.
+ *
+ * An empty -notify yields a no-op Notifier, so callers never need to
+ * nil-check it.
+ */
+func New(f *config.Flags) (Notifier, error) {
+	if strings.TrimSpace(f.NotifyProviders) == "" {
+		return noop{}, nil
+	}
+
+	var notifiers []Notifier
+	for _, name := range strings.Split(f.NotifyProviders, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "slack":
+			if f.SlackWebhookURL == "" {
+				return nil, fmt.Errorf("notify: slack selected but -slack-webhook-url is empty")
+			}
+			notifiers = append(notifiers, NewSlackNotifier(f.SlackWebhookURL))
+		case "discord":
+			if f.DiscordWebhookURL == "" {
+				return nil, fmt.Errorf("notify: discord selected but -discord-webhook-url is empty")
+			}
+			notifiers = append(notifiers, NewDiscordNotifier(f.DiscordWebhookURL))
+		case "telegram":
+			if f.TelegramBotToken == "" || f.TelegramChatID == "" {
+				return nil, fmt.Errorf("notify: telegram selected but -telegram-bot-token/-telegram-chat-id is empty")
+			}
+			notifiers = append(notifiers, NewTelegramNotifier(f.TelegramBotToken, f.TelegramChatID))
+		case "webhook":
+			if f.WebhookURL == "" {
+				return nil, fmt.Errorf("notify: webhook selected but -webhook-url is empty")
+			}
+			notifiers = append(notifiers, NewWebhookNotifier(f.WebhookURL, f.WebhookTemplate))
+		default:
+			return nil, fmt.Errorf("notify: unknown provider %q", name)
+		}
+	}
+
+	dedupWindow := f.NotifyDedupWindow
+	if dedupWindow == 0 {
+		dedupWindow = 10 * time.Minute
+	}
+
+	return newDeduper(multi(notifiers), dedupWindow), nil
+}
+
+// noop is returned when notifications are disabled, so filters can always
+// call Send without a nil check.
+type noop struct{}
+
+func (noop) Send(ctx context.Context, f Finding) error { return nil }
+
+// multi fans a single Send out to every configured provider, collecting
+// (but not stopping on) individual errors.
+type multi []Notifier
+
+func (m multi) Send(ctx context.Context, f Finding) error {
+	var errs []string
+	for _, n := range m {
+		if err := n.Send(ctx, f); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("notify: %v", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+/*
+ * deduper skips findings that share a checksum, type, param and detail with one
+ * sent within the last window, so a long crawl re-hitting the same
+ * endpoint doesn't spam every configured channel.
+ */
+type deduper struct {
+	next   Notifier
+	window time.Duration
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+func newDeduper(next Notifier, window time.Duration) *deduper {
+	return &deduper{
+		next:   next,
+		window: window,
+		seen:   make(map[string]time.Time),
+	}
+}
+
+func (d *deduper) Send(ctx context.Context, f Finding) error {
+	// Type and Detail are part of the key because several findings on
+	// one response share a checksum and an empty Param (e.g. scanSecrets
+	// and auditHeaders both fire with Param == ""), and Type alone still
+	// collapses distinct Header-Audit findings (missing CSP vs missing
+	// HSTS) into one key — without both, the first finding on a response
+	// would suppress every other kind for the rest of the window.
+	key := f.Checksum + "|" + f.Type + "|" + f.Param + "|" + f.Detail
+
+	d.mu.Lock()
+	last, ok := d.seen[key]
+	now := time.Now()
+	if ok && now.Sub(last) < d.window {
+		d.mu.Unlock()
+		return nil
+	}
+	d.seen[key] = now
+	d.mu.Unlock()
+
+	return d.next.Send(ctx, f)
+}
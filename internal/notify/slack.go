@@ -0,0 +1,97 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+/*
+ * SlackNotifier posts a Finding as a block-kit message, so the URL,
+ * method, param, checksum and snippet each get their own field instead
+ * of being squeezed into one line of text.
+ */
+type SlackNotifier struct {
+	WebhookURL string
+	httpClient *http.Client
+}
+
+func NewSlackNotifier(webhookURL string) *SlackNotifier {
+	return &SlackNotifier{
+		WebhookURL: webhookURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type slackMessage struct {
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type   string      `json:"type"`
+	Text   *slackText  `json:"text,omitempty"`
+	Fields []slackText `json:"fields,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+func (s *SlackNotifier) Send(ctx context.Context, f Finding) error {
+	msg := slackMessage{
+		Blocks: []slackBlock{
+			{
+				Type: "section",
+				Text: &slackText{Type: "mrkdwn", Text: fmt.Sprintf("*%v*", f.Type)},
+			},
+			{
+				Type: "section",
+				Fields: []slackText{
+					{Type: "mrkdwn", Text: fmt.Sprintf("*URL:*\n%v", f.URL)},
+					{Type: "mrkdwn", Text: fmt.Sprintf("*Method:*\n%v", f.Method)},
+					{Type: "mrkdwn", Text: fmt.Sprintf("*Param:*\n%v", f.Param)},
+					{Type: "mrkdwn", Text: fmt.Sprintf("*Checksum:*\n`%v`", f.Checksum)},
+				},
+			},
+		},
+	}
+	if f.Detail != "" {
+		msg.Blocks = append(msg.Blocks, slackBlock{
+			Type: "section",
+			Text: &slackText{Type: "mrkdwn", Text: f.Detail},
+		})
+	}
+	if f.Snippet != "" {
+		msg.Blocks = append(msg.Blocks, slackBlock{
+			Type: "section",
+			Text: &slackText{Type: "mrkdwn", Text: fmt.Sprintf("```%v```", f.Snippet)},
+		})
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.WebhookURL, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.New("notify: non-ok response returned from Slack")
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+/*
+ * TelegramNotifier posts a Finding as a Markdown message via the Telegram
+ * Bot API's sendMessage call.
+ */
+type TelegramNotifier struct {
+	BotToken   string
+	ChatID     string
+	httpClient *http.Client
+}
+
+func NewTelegramNotifier(botToken, chatID string) *TelegramNotifier {
+	return &TelegramNotifier{
+		BotToken:   botToken,
+		ChatID:     chatID,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (t *TelegramNotifier) Send(ctx context.Context, f Finding) error {
+	text := fmt.Sprintf("*%v*\nURL: `%v`\nMethod: %v\nParam: `%v`\nChecksum: `%v`",
+		f.Type, f.URL, f.Method, f.Param, f.Checksum)
+	if f.Detail != "" {
+		text += fmt.Sprintf("\n%v", f.Detail)
+	}
+	if f.Snippet != "" {
+		text += fmt.Sprintf("\n```\n%v\n```", f.Snippet)
+	}
+
+	endpoint := fmt.Sprintf("https://api.telegram.org/bot%v/sendMessage", t.BotToken)
+	form := url.Values{
+		"chat_id":    {t.ChatID},
+		"text":       {text},
+		"parse_mode": {"Markdown"},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.URL.RawQuery = form.Encode()
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("notify: telegram bot api returned status %v", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+/*
+ * WebhookNotifier POSTs a Finding to an arbitrary HTTP endpoint, rendered
+ * through a simple {{placeholder}} template so it can be wired into
+ * whatever JSON shape the receiving system expects.
+ *
+ * Supported placeholders: {{type}}, {{url}}, {{method}}, {{param}},
+ * {{checksum}}, {{snippet}}, {{detail}}.
+ *
+ * An empty template defaults to a plain JSON object with those same
+ * fields.
+ */
+type WebhookNotifier struct {
+	URL        string
+	Template   string
+	httpClient *http.Client
+}
+
+const defaultWebhookTemplate = `{` +
+	`"type":"{{type}}",` +
+	`"url":"{{url}}",` +
+	`"method":"{{method}}",` +
+	`"param":"{{param}}",` +
+	`"checksum":"{{checksum}}",` +
+	`"snippet":"{{snippet}}",` +
+	`"detail":"{{detail}}"` +
+	`}`
+
+func NewWebhookNotifier(webhookURL, template string) *WebhookNotifier {
+	if template == "" {
+		template = defaultWebhookTemplate
+	}
+	return &WebhookNotifier{
+		URL:        webhookURL,
+		Template:   template,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (w *WebhookNotifier) Send(ctx context.Context, f Finding) error {
+	body := render(w.Template, f)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewBufferString(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notify: webhook returned status %v", resp.StatusCode)
+	}
+	return nil
+}
+
+func render(template string, f Finding) string {
+	replacer := strings.NewReplacer(
+		"{{type}}", jsonEscape(f.Type),
+		"{{url}}", jsonEscape(f.URL),
+		"{{method}}", jsonEscape(f.Method),
+		"{{param}}", jsonEscape(f.Param),
+		"{{checksum}}", jsonEscape(f.Checksum),
+		"{{snippet}}", jsonEscape(f.Snippet),
+		"{{detail}}", jsonEscape(f.Detail),
+	)
+	return replacer.Replace(template)
+}
+
+// jsonEscape keeps the template substitution from producing invalid JSON
+// when a finding value contains a quote, backslash, or control character
+// such as a raw newline — snippets and details are excerpted verbatim
+// from request/response bodies, so any of those can show up.
+func jsonEscape(s string) string {
+	quoted, err := json.Marshal(s)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(string(quoted), `"`), `"`)
+}
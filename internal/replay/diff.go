@@ -0,0 +1,140 @@
+package replay
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// volatileFieldRe names JSON object keys whose values legitimately differ
+// between two otherwise-identical responses (CSRF tokens, timestamps);
+// normalize blanks those values so they don't mask a genuine content match.
+var volatileFieldRe = regexp.MustCompile(`(?i)^(csrf|xsrf|token|timestamp|date|nonce|requestid|request_id)$`)
+
+/*
+ * DiffResult summarizes how two responses to the same request, issued
+ * under two different identities, compare.
+ */
+type DiffResult struct {
+	StatusesMatch  bool
+	SimilarContent bool
+	Similarity     float64 // 0..1, fraction of tokens the two bodies share
+}
+
+// similarityThreshold is how much of the tokenized body has to match
+// before two responses are considered "substantially similar" rather
+// than two different pages that both happened to 200.
+const similarityThreshold = 0.8
+
+// maxDiffBodyBytes/maxDiffTokens bound how much of each body the LCS
+// pass below ever sees. lcsLength is O(len(a)*len(b)); callers pass up
+// to DefaultBodyCap (1MiB) of each body, which can run to well over a
+// hundred thousand tokens, so without a cap here two large responses
+// can pin a replay goroutine for minutes.
+const (
+	maxDiffBodyBytes = 64 * 1024
+	maxDiffTokens    = 2000
+)
+
+// Diff tokenizes both bodies (normalizing away volatile JSON fields
+// first) and compares them via a longest-common-subsequence pass, the
+// same diffing primitive Myers' algorithm is built on.
+func Diff(origStatus int, origBody []byte, altStatus int, altBody []byte) DiffResult {
+	origTokens := truncateTokens(tokenize(normalize(truncateBytes(origBody, maxDiffBodyBytes))), maxDiffTokens)
+	altTokens := truncateTokens(tokenize(normalize(truncateBytes(altBody, maxDiffBodyBytes))), maxDiffTokens)
+
+	lcs := lcsLength(origTokens, altTokens)
+	total := len(origTokens) + len(altTokens)
+
+	similarity := 1.0
+	if total > 0 {
+		similarity = 2 * float64(lcs) / float64(total)
+	}
+
+	return DiffResult{
+		StatusesMatch:  origStatus == altStatus,
+		SimilarContent: similarity >= similarityThreshold,
+		Similarity:     similarity,
+	}
+}
+
+// normalize walks the body as JSON and blanks out volatile fields; if
+// the body isn't JSON it's returned unchanged, since CSRF/timestamp
+// stripping is a JSON-specific affordance here.
+func normalize(body []byte) []byte {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+
+	stripVolatile(v)
+
+	out, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+func stripVolatile(v interface{}) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for key, nested := range val {
+			if volatileFieldRe.MatchString(key) {
+				val[key] = ""
+				continue
+			}
+			stripVolatile(nested)
+		}
+	case []interface{}:
+		for _, item := range val {
+			stripVolatile(item)
+		}
+	}
+}
+
+func tokenize(body []byte) []string {
+	return strings.Fields(string(body))
+}
+
+func truncateBytes(b []byte, max int) []byte {
+	if len(b) > max {
+		return b[:max]
+	}
+	return b
+}
+
+func truncateTokens(t []string, max int) []string {
+	if len(t) > max {
+		return t[:max]
+	}
+	return t
+}
+
+// lcsLength returns the length of the longest common subsequence of a
+// and b via the classic O(len(a)*len(b)) dynamic program. Diff truncates
+// both token lists to maxDiffTokens before calling this, so the
+// quadratic table stays small regardless of how big the original
+// response bodies were.
+func lcsLength(a, b []string) int {
+	rows := len(a) + 1
+	cols := len(b) + 1
+
+	prev := make([]int, cols)
+	curr := make([]int, cols)
+
+	for i := 1; i < rows; i++ {
+		for j := 1; j < cols; j++ {
+			if a[i-1] == b[j-1] {
+				curr[j] = prev[j-1] + 1
+			} else if prev[j] >= curr[j-1] {
+				curr[j] = prev[j]
+			} else {
+				curr[j] = curr[j-1]
+			}
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[cols-1]
+}
@@ -0,0 +1,88 @@
+package replay
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+/*
+ * Identity is the second set of credentials ConfirmIDOR/replay swap into
+ * a captured request: a cookie jar and/or an Authorization header taken
+ * from a logged-in session that shouldn't have access to the resource
+ * the original request's identity does.
+ */
+type Identity struct {
+	Cookies       []*http.Cookie
+	Authorization string
+}
+
+/*
+ * LoadIdentity reads a -alt-auth file, one "Header-Name: value" pair per
+ * line. A "Cookie" line is parsed the same way a browser would send one
+ * (name=value; name=value), an "Authorization" line sets the
+ * Authorization header, and any other header line is ignored.
+ */
+func LoadIdentity(path string) (*Identity, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading alt-auth file: %w", err)
+	}
+	defer f.Close()
+
+	identity := &Identity{}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+
+		if strings.EqualFold(name, "Cookie") {
+			identity.Cookies = append(identity.Cookies, parseCookiePairs(value)...)
+			continue
+		}
+		if strings.EqualFold(name, "Authorization") {
+			identity.Authorization = value
+			continue
+		}
+	}
+
+	return identity, scanner.Err()
+}
+
+func parseCookiePairs(header string) []*http.Cookie {
+	var cookies []*http.Cookie
+	for _, pair := range strings.Split(header, ";") {
+		name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			continue
+		}
+		cookies = append(cookies, &http.Cookie{Name: strings.TrimSpace(name), Value: strings.TrimSpace(value)})
+	}
+	return cookies
+}
+
+// Apply swaps req's auth-bearing headers/cookies for the identity's,
+// leaving everything else (method, URL, body, other headers) untouched.
+func (id *Identity) Apply(req *http.Request) {
+	req.Header.Del("Cookie")
+	req.Header.Del("Authorization")
+
+	for _, c := range id.Cookies {
+		req.AddCookie(c)
+	}
+	if id.Authorization != "" {
+		req.Header.Set("Authorization", id.Authorization)
+	}
+}
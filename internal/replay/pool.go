@@ -0,0 +1,37 @@
+package replay
+
+import "sync"
+
+// Pool bounds how many replay requests are in flight at once, so
+// confirming a long list of IDOR candidates doesn't turn into a second
+// uncontrolled crawl against the target.
+type Pool struct {
+	sem chan struct{}
+	wg  sync.WaitGroup
+}
+
+func NewPool(concurrency int) *Pool {
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	return &Pool{sem: make(chan struct{}, concurrency)}
+}
+
+// Go blocks until a slot is free, then runs fn in its own goroutine and
+// releases the slot when fn returns.
+func (p *Pool) Go(fn func()) {
+	p.sem <- struct{}{}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer func() { <-p.sem }()
+		fn()
+	}()
+}
+
+// Wait blocks until every fn passed to Go has returned. Callers that
+// never need to drain the pool (e.g. a long-running proxy filter) can
+// simply never call it.
+func (p *Pool) Wait() {
+	p.wg.Wait()
+}
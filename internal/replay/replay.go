@@ -0,0 +1,77 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// DefaultBodyCap bounds how much of a response body ReadCapped will
+// buffer. Callers diffing a replayed response against the original
+// should always read through this rather than ioutil.ReadAll, since
+// neither body size is otherwise bounded.
+const DefaultBodyCap = 1 << 20 // 1MiB
+
+// ReadCapped reads at most max bytes from r. Diff further truncates
+// whatever comes back before tokenizing, so this mainly guards against
+// buffering an unbounded response into memory in the first place.
+func ReadCapped(r io.Reader, max int64) ([]byte, error) {
+	return ioutil.ReadAll(io.LimitReader(r, max))
+}
+
+/*
+ * Replayer re-issues a captured request under a second Identity, so a
+ * flagged param can be confirmed as exploitable instead of just
+ * suspicious-by-name.
+ */
+type Replayer struct {
+	Identity *Identity
+	Pool     *Pool
+
+	httpClient *http.Client
+}
+
+func NewReplayer(identity *Identity, concurrency int) *Replayer {
+	return &Replayer{
+		Identity:   identity,
+		Pool:       NewPool(concurrency),
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// Replay clones req's method, URL, headers and body, swaps in the alt
+// identity, and issues it. The original request is left untouched.
+//
+// req.Body is cloned as-is, which only works if it hasn't already been
+// read (e.g. a request built directly from a capture file, body intact).
+// For a request whose body was already consumed upstream of the
+// caller — such as ctx.Req in a goproxy response filter — use
+// ReplayWithBody instead.
+func (r *Replayer) Replay(ctx context.Context, req *http.Request) (*http.Response, error) {
+	clone := req.Clone(ctx)
+	clone.RequestURI = ""
+	r.Identity.Apply(clone)
+
+	return r.httpClient.Do(clone)
+}
+
+// ReplayWithBody is Replay, but with the clone's body explicitly
+// rehydrated from body rather than inherited (shallow-copied) from req.
+// Use this whenever req.Body may already be drained/closed, e.g. a
+// goproxy response filter's ctx.Req, whose body goproxy's transport
+// already read and closed while forwarding the original request.
+func (r *Replayer) ReplayWithBody(ctx context.Context, req *http.Request, body []byte) (*http.Response, error) {
+	clone := req.Clone(ctx)
+	clone.RequestURI = ""
+	clone.ContentLength = int64(len(body))
+	clone.Body = ioutil.NopCloser(bytes.NewReader(body))
+	clone.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(body)), nil
+	}
+	r.Identity.Apply(clone)
+
+	return r.httpClient.Do(clone)
+}
@@ -0,0 +1,279 @@
+package har
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+/*
+ * HAR 1.2 document structure, as consumed by Chrome DevTools,
+ * mitmproxy and Burp. See http://www.softwareishard.com/blog/har-12-spec/
+ */
+type HAR struct {
+	Log Log `json:"log"`
+}
+
+type Log struct {
+	Version string  `json:"version"`
+	Creator Creator `json:"creator"`
+	Entries []Entry `json:"entries"`
+}
+
+type Creator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type Entry struct {
+	StartedDateTime string   `json:"startedDateTime"`
+	Time            float64  `json:"time"`
+	Request         Request  `json:"request"`
+	Response        Response `json:"response"`
+	Cache           Cache    `json:"cache"`
+	Timings         Timings  `json:"timings"`
+}
+
+type Request struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	HTTPVersion string      `json:"httpVersion"`
+	Headers     []NameValue `json:"headers"`
+	QueryString []NameValue `json:"queryString"`
+	PostData    *PostData   `json:"postData,omitempty"`
+	HeadersSize int         `json:"headersSize"`
+	BodySize    int         `json:"bodySize"`
+}
+
+type Response struct {
+	Status      int         `json:"status"`
+	StatusText  string      `json:"statusText"`
+	HTTPVersion string      `json:"httpVersion"`
+	Headers     []NameValue `json:"headers"`
+	Content     Content     `json:"content"`
+	RedirectURL string      `json:"redirectURL"`
+	HeadersSize int         `json:"headersSize"`
+	BodySize    int         `json:"bodySize"`
+}
+
+type NameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type PostData struct {
+	MimeType string      `json:"mimeType"`
+	Text     string      `json:"text"`
+	Params   []NameValue `json:"params,omitempty"`
+}
+
+type Content struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// Cache and Timings are not tracked by ponieproxy, but the fields are
+// required by the HAR schema, so we emit empty stubs.
+type Cache struct{}
+
+type Timings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+/*
+ * Recorder accumulates request/response pairs, correlated by the proxy's
+ * SHA1 checksum, and periodically flushes them into a HAR file.
+ *
+ * A request is buffered in pending until its matching response arrives,
+ * at which point it becomes a complete Entry.
+ */
+type Recorder struct {
+	outFile string
+
+	mu      sync.Mutex
+	pending map[string]*pendingEntry
+	entries []Entry
+}
+
+type pendingEntry struct {
+	startedDateTime time.Time
+	request         Request
+}
+
+// NewRecorder builds a Recorder that flushes to outFile every
+// flushInterval. An empty outFile means HAR export is disabled (-har-out
+// wasn't set), so no flush ticker is started, AddRequest buffers nothing
+// (so AddResponse finds no pending request and drops the response), and
+// Flush is a no-op.
+func NewRecorder(outFile string, flushInterval time.Duration) *Recorder {
+	r := &Recorder{
+		outFile: outFile,
+		pending: make(map[string]*pendingEntry),
+	}
+
+	if outFile == "" {
+		return r
+	}
+
+	go func() {
+		ticker := time.NewTicker(flushInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := r.Flush(); err != nil {
+				fmt.Printf("error flushing har file: %v\n", err)
+			}
+		}
+	}()
+
+	return r
+}
+
+/*
+ * AddRequest buffers the request side of a pair, keyed by checksum.
+ * It is safe to call from multiple goroutines.
+ */
+func (r *Recorder) AddRequest(checksum string, req *http.Request, reqBody []byte) {
+	if r.outFile == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pending[checksum] = &pendingEntry{
+		startedDateTime: time.Now(),
+		request:         toHARRequest(req, reqBody),
+	}
+}
+
+/*
+ * AddResponse matches a response against its buffered request by checksum
+ * and appends the completed entry. Responses with no matching request
+ * (e.g. the recorder was started mid-capture) are dropped.
+ */
+func (r *Recorder) AddResponse(checksum string, res *http.Response, resBody []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.pending[checksum]
+	if !ok {
+		return
+	}
+	delete(r.pending, checksum)
+
+	r.entries = append(r.entries, Entry{
+		StartedDateTime: p.startedDateTime.Format(time.RFC3339Nano),
+		Time:            float64(time.Since(p.startedDateTime).Milliseconds()),
+		Request:         p.request,
+		Response:        toHARResponse(res, resBody),
+		Cache:           Cache{},
+		Timings:         Timings{Send: 0, Wait: 0, Receive: 0},
+	})
+}
+
+/*
+ * Flush writes all entries accumulated so far to the HAR file on disk,
+ * overwriting it. Safe to call concurrently with AddRequest/AddResponse.
+ * A no-op when the Recorder has no outFile (HAR export disabled).
+ */
+func (r *Recorder) Flush() error {
+	if r.outFile == "" {
+		return nil
+	}
+
+	r.mu.Lock()
+	doc := HAR{
+		Log: Log{
+			Version: "1.2",
+			Creator: Creator{Name: "ponieproxy", Version: "1.0"},
+			Entries: append([]Entry(nil), r.entries...),
+		},
+	}
+	r.mu.Unlock()
+
+	f, err := os.Create(r.outFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+func toHARRequest(req *http.Request, reqBody []byte) Request {
+	query := req.URL.Query()
+	queryString := make([]NameValue, 0, len(query))
+	for name, values := range query {
+		for _, v := range values {
+			queryString = append(queryString, NameValue{Name: name, Value: v})
+		}
+	}
+
+	var postData *PostData
+	if len(reqBody) > 0 {
+		postData = &PostData{
+			MimeType: req.Header.Get("Content-Type"),
+			Text:     string(reqBody),
+		}
+	}
+
+	return Request{
+		Method:      req.Method,
+		URL:         req.URL.String(),
+		HTTPVersion: req.Proto,
+		Headers:     toNameValues(req.Header),
+		QueryString: queryString,
+		PostData:    postData,
+		HeadersSize: headersSize(req.Header),
+		BodySize:    len(reqBody),
+	}
+}
+
+func toHARResponse(res *http.Response, resBody []byte) Response {
+	mimeType := res.Header.Get("Content-Type")
+	return Response{
+		Status:      res.StatusCode,
+		StatusText:  http.StatusText(res.StatusCode),
+		HTTPVersion: res.Proto,
+		Headers:     toNameValues(res.Header),
+		Content: Content{
+			Size:     len(resBody),
+			MimeType: mimeType,
+			Text:     string(resBody),
+		},
+		RedirectURL: res.Header.Get("Location"),
+		HeadersSize: headersSize(res.Header),
+		BodySize:    len(resBody),
+	}
+}
+
+func toNameValues(h http.Header) []NameValue {
+	out := make([]NameValue, 0, len(h))
+	for name, values := range h {
+		for _, v := range values {
+			out = append(out, NameValue{Name: name, Value: v})
+		}
+	}
+	return out
+}
+
+// headersSize approximates the wire size of a header block the way
+// DumpRequest/DumpResponse would render it, which is good enough for the
+// HAR viewer and avoids re-serializing the whole message just to count bytes.
+func headersSize(h http.Header) int {
+	size := 0
+	for name, values := range h {
+		for _, v := range values {
+			size += len(name) + len(v) + 4 // "Name: Value\r\n"
+		}
+	}
+	return size
+}